@@ -20,21 +20,48 @@ type dataResponse struct {
 
 var ErrWordnetNotLoaded = errors.New("Wordnet is not loaded.")
 
-// Load loads the wordnet database into memory.
+// Load loads a wordnet database into memory and returns a JSON-formatted
+// string of the form {"handle": "<id>", "error": ""}. The handle can be
+// passed to the *WithHandle functions to address this instance directly,
+// which allows multiple wordnet distributions (e.g. English plus a
+// domain-specific extension) to be loaded side-by-side. The first
+// successfully loaded instance also becomes the default instance used by
+// the no-handle functions, for backwards compatibility.
 func Load(dirName string) string {
-	if wordnet != nil {
-		return makeJSONError(nil)
+	return LoadWithOptions(dirName, Options{})
+}
+
+// LoadWithOptions loads a wordnet database into memory and returns a
+// handle/error JSON response exactly like Load. The Options (currently the
+// LRU cache configuration) take effect only when this call makes the
+// instance the default one; loading a further instance leaves the cache as
+// it is. Calling it again with a dirName that's already loaded reuses the
+// existing handle (making it the default again if there is none) instead
+// of reparsing the directory and registering a duplicate instance.
+func LoadWithOptions(dirName string, opts Options) string {
+	if handle, ok := handleForDir(dirName); ok {
+		if wn, ok := lookupHandle(handle); ok && wordnet == nil {
+			wordnet = wn
+			defaultHandle = handle
+			configureCache(opts)
+			precomputeMaxDepths(wn)
+		}
+		return makeLoadResponse(handle, nil)
 	}
 
-	var (
-		err error
-		wn  *gown.WN
-	)
+	wn, err := load(dirName)
+	if err != nil {
+		return makeLoadResponse("", err)
+	}
 
-	if wn, err = load(dirName); err == nil {
+	handle := registerWN(dirName, wn)
+	if wordnet == nil {
 		wordnet = wn
+		defaultHandle = handle
+		configureCache(opts)
+		precomputeMaxDepths(wn)
 	}
-	return makeJSONError(err)
+	return makeLoadResponse(handle, nil)
 }
 
 func load(dirName string) (*gown.WN, error) {
@@ -53,7 +80,13 @@ func LookupWithPartOfSpeech(lemma string, pos int) string {
 	if wordnet == nil {
 		return makeJSONError(ErrWordnetNotLoaded)
 	}
-	return makeJSONResponse(wordnet.LookupWithPartOfSpeech(lemma, pos))
+	key := cacheKey{op: "LookupWithPartOfSpeech", lemma: lemma, pos: pos}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+	res := makeJSONResponse(wordnet.LookupWithPartOfSpeech(lemma, pos))
+	cacheSet(key, res)
+	return res
 }
 
 // LookupSensesWithPartOfSpeech returns a JSON-formatted string of the sense
@@ -62,7 +95,13 @@ func LookupSensesWithPartOfSpeech(lemma string, pos int) string {
 	if wordnet == nil {
 		return makeJSONError(ErrWordnetNotLoaded)
 	}
-	return makeJSONResponse(wordnet.LookupSensesWithPartOfSpeech(lemma, pos))
+	key := cacheKey{op: "LookupSensesWithPartOfSpeech", lemma: lemma, pos: pos}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+	res := makeJSONResponse(wordnet.LookupSensesWithPartOfSpeech(lemma, pos))
+	cacheSet(key, res)
+	return res
 }
 
 // LookupWithPartOfSpeechAndSense returns a JSON-formmatted string of the
@@ -71,7 +110,13 @@ func LookupWithPartOfSpeechAndSense(lemma string, pos int, senseID int) string {
 	if wordnet == nil {
 		return makeJSONError(ErrWordnetNotLoaded)
 	}
-	return makeJSONResponse(wordnet.LookupWithPartOfSpeechAndSense(lemma, pos, senseID))
+	key := cacheKey{op: "LookupWithPartOfSpeechAndSense", lemma: lemma, pos: pos, senseID: senseID}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+	res := makeJSONResponse(wordnet.LookupWithPartOfSpeechAndSense(lemma, pos, senseID))
+	cacheSet(key, res)
+	return res
 }
 
 // Lookup returns a JSON-formmatted string of the list of sense index entries of
@@ -80,7 +125,13 @@ func Lookup(lemma string) string {
 	if wordnet == nil {
 		return makeJSONError(ErrWordnetNotLoaded)
 	}
-	return makeJSONResponse(wordnet.Lookup(lemma))
+	key := cacheKey{op: "Lookup", lemma: lemma}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+	res := makeJSONResponse(wordnet.Lookup(lemma))
+	cacheSet(key, res)
+	return res
 }
 
 // GetSynset returns a JSON-formatted string of the synset(data) in database.
@@ -88,7 +139,13 @@ func GetSynset(pos int, synsetOffset int) string {
 	if wordnet == nil {
 		return makeJSONError(ErrWordnetNotLoaded)
 	}
-	return makeJSONResponse(wordnet.GetSynset(pos, synsetOffset))
+	key := cacheKey{op: "GetSynset", pos: pos, offset: synsetOffset}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+	res := makeJSONResponse(wordnet.GetSynset(pos, synsetOffset))
+	cacheSet(key, res)
+	return res
 }
 
 // GetSynsetsWithLemma returns a JSON-formatted string which contains
@@ -142,11 +199,22 @@ func getSynsetsWithLemmaAndPos(wn *gown.WN, lemma string, pos int) (synsets []*g
 // Morph returns a JSON-formatted string of a list of possible words which
 // are a result of the original word being morphologically processed.
 func Morph(origWord string) string {
-	if morphedPosMap, err := morph(wordnet, origWord); err != nil {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	key := cacheKey{op: "Morph", lemma: origWord}.String()
+	if cached, ok := cacheGet(key); ok {
+		return cached
+	}
+
+	morphedPosMap, err := morph(wordnet, origWord)
+	if err != nil {
 		return makeJSONError(err)
-	} else {
-		return makeJSONResponse(morphedPosMap)
 	}
+
+	res := makeJSONResponse(morphedPosMap)
+	cacheSet(key, res)
+	return res
 }
 
 func morph(wn *gown.WN, origWord string) (morphedPosMap map[string][]int, err error) {
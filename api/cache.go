@@ -0,0 +1,187 @@
+package wordnet
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultCacheSize     = 10000
+	defaultMaxCacheBytes = 64 * 1024 * 1024
+)
+
+// Options configures the behavior of LoadWithOptions.
+type Options struct {
+	// CacheEnabled turns on the in-memory LRU cache for Lookup*, GetSynset
+	// and Morph results. Defaults to disabled.
+	CacheEnabled bool
+
+	// CacheSize is the maximum number of entries the cache holds. A
+	// value <= 0 falls back to a sensible default.
+	CacheSize int
+
+	// CacheMaxBytes is the maximum total size, in bytes, of cached JSON
+	// values. A value <= 0 falls back to a sensible default.
+	CacheMaxBytes int
+
+	// OnEvict, when set, is called with the key of every entry evicted
+	// because the cache exceeded CacheSize or CacheMaxBytes. It is not
+	// called for entries dropped when a later load replaces the cache, and
+	// Unload does not touch the cache.
+	OnEvict func(key string)
+}
+
+type cacheKey struct {
+	op      string
+	lemma   string
+	pos     int
+	senseID int
+	offset  int
+}
+
+func (k cacheKey) String() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%d", k.op, k.lemma, k.pos, k.senseID, k.offset)
+}
+
+type cacheStats struct {
+	Hits        int `json:"hits"`
+	Misses      int `json:"misses"`
+	Evictions   int `json:"evictions"`
+	CurrentSize int `json:"current_size"`
+}
+
+type cacheEntry struct {
+	key   string
+	value string
+}
+
+// lruCache is a size- and byte-bounded least-recently-used cache of JSON
+// response strings.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int
+	curBytes   int
+	onEvict    func(key string)
+
+	ll    *list.List
+	items map[string]*list.Element
+
+	hits, misses, evictions int
+}
+
+func newLRUCache(maxEntries int, maxBytes int, onEvict func(key string)) *lruCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheSize
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxCacheBytes
+	}
+	return &lruCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		onEvict:    onEvict,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+	}
+}
+
+func (c *lruCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return "", false
+	}
+	c.ll.MoveToFront(elem)
+	c.hits++
+	return elem.Value.(*cacheEntry).value, true
+}
+
+func (c *lruCache) add(key string, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		c.curBytes += len(value) - len(entry.value)
+		entry.value = value
+		c.ll.MoveToFront(elem)
+	} else {
+		elem := c.ll.PushFront(&cacheEntry{key: key, value: value})
+		c.items[key] = elem
+		c.curBytes += len(key) + len(value)
+	}
+
+	for (c.ll.Len() > c.maxEntries || c.curBytes > c.maxBytes) && c.ll.Len() > 0 {
+		c.removeOldest()
+	}
+}
+
+func (c *lruCache) removeOldest() {
+	elem := c.ll.Back()
+	if elem == nil {
+		return
+	}
+	entry := elem.Value.(*cacheEntry)
+	c.ll.Remove(elem)
+	delete(c.items, entry.key)
+	c.curBytes -= len(entry.key) + len(entry.value)
+	c.evictions++
+	if c.onEvict != nil {
+		c.onEvict(entry.key)
+	}
+}
+
+func (c *lruCache) stats() cacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return cacheStats{
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		CurrentSize: c.ll.Len(),
+	}
+}
+
+var (
+	packageCache *lruCache
+	cacheEnabled bool
+)
+
+func configureCache(opts Options) {
+	cacheEnabled = opts.CacheEnabled
+	if !opts.CacheEnabled {
+		packageCache = nil
+		return
+	}
+	packageCache = newLRUCache(opts.CacheSize, opts.CacheMaxBytes, opts.OnEvict)
+}
+
+func cacheGet(key string) (string, bool) {
+	if !cacheEnabled || packageCache == nil {
+		return "", false
+	}
+	return packageCache.get(key)
+}
+
+func cacheSet(key string, value string) {
+	if !cacheEnabled || packageCache == nil {
+		return
+	}
+	packageCache.add(key, value)
+}
+
+// CacheStats returns a JSON-formatted string describing the current state
+// of the package's LRU cache: hits, misses, evictions and current size. If
+// the cache is disabled, all fields are zero.
+func CacheStats() string {
+	if packageCache == nil {
+		return makeJSONResponse(cacheStats{})
+	}
+	return makeJSONResponse(packageCache.stats())
+}
@@ -0,0 +1,42 @@
+package wordnet
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	var evicted []string
+	c := newLRUCache(2, 0, func(key string) {
+		evicted = append(evicted, key)
+	})
+
+	c.add("a", "1")
+	c.add("b", "2")
+	c.add("c", "3")
+
+	Convey("Test the oldest entry is evicted once capacity is exceeded.", t, func() {
+		_, ok := c.get("a")
+		So(ok, ShouldBeFalse)
+		So(evicted, ShouldResemble, []string{"a"})
+
+		v, ok := c.get("b")
+		So(ok, ShouldBeTrue)
+		So(v, ShouldEqual, "2")
+	})
+}
+
+func TestLRUCacheStats(t *testing.T) {
+	c := newLRUCache(10, 0, nil)
+	c.add("a", "1")
+	c.get("a")
+	c.get("unknown")
+
+	Convey("Test hit/miss counters are tracked.", t, func() {
+		stats := c.stats()
+		So(stats.Hits, ShouldEqual, 1)
+		So(stats.Misses, ShouldEqual, 1)
+		So(stats.CurrentSize, ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,174 @@
+package wordnet
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/wolfgangshilei/gown"
+)
+
+// ErrUnknownHandle is returned when a handle passed to a *WithHandle
+// function does not correspond to a wordnet loaded via Load or
+// LoadWithOptions.
+var ErrUnknownHandle = errors.New("Unknown wordnet handle.")
+
+type loadResponse struct {
+	Handle string `json:"handle"`
+	Error  string `json:"error"`
+}
+
+type registryEntry struct {
+	wn      *gown.WN
+	dirName string
+}
+
+var (
+	registryMu    sync.Mutex
+	registry      = map[string]*registryEntry{}
+	registryByDir = map[string]string{}
+	nextHandleID  uint64
+	defaultHandle string
+)
+
+// registerWN registers wn under a new handle, unless dirName was already
+// loaded, in which case the existing handle is reused so that repeated
+// Load calls for the same directory (e.g. from a server's /load endpoint)
+// don't accumulate orphaned *gown.WN instances in the registry.
+func registerWN(dirName string, wn *gown.WN) string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if handle, ok := registryByDir[dirName]; ok {
+		return handle
+	}
+
+	nextHandleID++
+	handle := strconv.FormatUint(nextHandleID, 10)
+	registry[handle] = &registryEntry{wn: wn, dirName: dirName}
+	registryByDir[dirName] = handle
+	return handle
+}
+
+// handleForDir returns the handle already registered for dirName, if any,
+// so LoadWithOptions can skip re-parsing a directory that's already loaded.
+func handleForDir(dirName string) (string, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	handle, ok := registryByDir[dirName]
+	return handle, ok
+}
+
+func lookupHandle(handle string) (*gown.WN, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	entry, ok := registry[handle]
+	if !ok {
+		return nil, false
+	}
+	return entry.wn, true
+}
+
+func unregisterHandle(handle string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	entry, ok := registry[handle]
+	if !ok {
+		return
+	}
+	delete(registry, handle)
+	delete(registryByDir, entry.dirName)
+}
+
+func makeLoadResponse(handle string, err error) string {
+	errString := ""
+	if err != nil {
+		errString = err.Error()
+	}
+
+	jres, _ := json.Marshal(loadResponse{Handle: handle, Error: errString})
+	return string(jres)
+}
+
+// Unload releases the wordnet instance associated with handle. If handle
+// is the default handle used by the no-handle functions, those functions
+// will return ErrWordnetNotLoaded until Load is called again.
+func Unload(handle string) string {
+	if _, ok := lookupHandle(handle); !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+
+	unregisterHandle(handle)
+	if handle == defaultHandle {
+		wordnet = nil
+		defaultHandle = ""
+	}
+	return makeJSONError(nil)
+}
+
+// LookupWithHandle is the handle-scoped variant of Lookup, letting callers
+// address a specific wordnet instance returned by Load/LoadWithOptions.
+func LookupWithHandle(handle string, lemma string) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+	return makeJSONResponse(wn.Lookup(lemma))
+}
+
+// LookupWithPartOfSpeechWithHandle is the handle-scoped variant of
+// LookupWithPartOfSpeech.
+func LookupWithPartOfSpeechWithHandle(handle string, lemma string, pos int) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+	return makeJSONResponse(wn.LookupWithPartOfSpeech(lemma, pos))
+}
+
+// LookupSensesWithPartOfSpeechWithHandle is the handle-scoped variant of
+// LookupSensesWithPartOfSpeech.
+func LookupSensesWithPartOfSpeechWithHandle(handle string, lemma string, pos int) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+	return makeJSONResponse(wn.LookupSensesWithPartOfSpeech(lemma, pos))
+}
+
+// LookupWithPartOfSpeechAndSenseWithHandle is the handle-scoped variant of
+// LookupWithPartOfSpeechAndSense.
+func LookupWithPartOfSpeechAndSenseWithHandle(handle string, lemma string, pos int, senseID int) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+	return makeJSONResponse(wn.LookupWithPartOfSpeechAndSense(lemma, pos, senseID))
+}
+
+// GetSynsetWithHandle is the handle-scoped variant of GetSynset.
+func GetSynsetWithHandle(handle string, pos int, synsetOffset int) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+	return makeJSONResponse(wn.GetSynset(pos, synsetOffset))
+}
+
+// MorphWithHandle is the handle-scoped variant of Morph.
+func MorphWithHandle(handle string, origWord string) string {
+	wn, ok := lookupHandle(handle)
+	if !ok {
+		return makeJSONError(ErrUnknownHandle)
+	}
+
+	morphedPosMap, err := morph(wn, origWord)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(morphedPosMap)
+}
@@ -0,0 +1,34 @@
+package wordnet
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnknownHandle(t *testing.T) {
+	Convey("Test lookup functions reject an unknown handle.", t, func() {
+		So(LookupWithHandle("no-such-handle", "test"), ShouldContainSubstring, ErrUnknownHandle.Error())
+		So(GetSynsetWithHandle("no-such-handle", 1, 1), ShouldContainSubstring, ErrUnknownHandle.Error())
+	})
+}
+
+func TestUnload(t *testing.T) {
+	Convey("Test unloading an unknown handle returns an error.", t, func() {
+		So(Unload("no-such-handle"), ShouldContainSubstring, ErrUnknownHandle.Error())
+	})
+}
+
+func TestRegisterWNDedupesByDir(t *testing.T) {
+	wn, err := load(dictDirName)
+	ensureWordnetLoaded(t, err)
+
+	first := registerWN(dictDirName, wn)
+	second := registerWN(dictDirName, wn)
+
+	Convey("Test loading the same directory twice reuses the same handle.", t, func() {
+		So(second, ShouldEqual, first)
+	})
+
+	unregisterHandle(first)
+}
@@ -0,0 +1,202 @@
+package wordnet
+
+import (
+	"fmt"
+
+	"github.com/wolfgangshilei/gown"
+)
+
+// Standard WordNet pointer symbols, as documented in the pointer tables for
+// nouns, verbs, adjectives and adverbs.
+const (
+	PtrHypernym      = "@"
+	PtrInstanceHyper = "@i"
+	PtrHyponym       = "~"
+	PtrInstanceHypo  = "~i"
+	PtrMemberMeronym = "%m"
+	PtrStuffMeronym  = "%s"
+	PtrPartMeronym   = "%p"
+	PtrMemberHolonym = "#m"
+	PtrStuffHolonym  = "#s"
+	PtrPartHolonym   = "#p"
+	PtrAntonym       = "!"
+	PtrDerivation    = "+"
+)
+
+var meronymCodes = []string{PtrMemberMeronym, PtrStuffMeronym, PtrPartMeronym}
+var holonymCodes = []string{PtrMemberHolonym, PtrStuffHolonym, PtrPartHolonym}
+
+type relatedSynset struct {
+	Offset int      `json:"offset"`
+	Pos    int      `json:"pos"`
+	Words  []string `json:"words"`
+}
+
+// closureNode is a node in the tree returned by TransitiveClosure: a
+// synset together with the children reached by following relationCode.
+type closureNode struct {
+	Offset   int            `json:"offset"`
+	Pos      int            `json:"pos"`
+	Words    []string       `json:"words"`
+	Children []*closureNode `json:"children,omitempty"`
+}
+
+// GetRelated returns a JSON-formatted string of the list of synsets reached
+// from the given synset by following the pointer relation identified by
+// relationCode (one of the standard WordNet pointer symbols, e.g. "@" for
+// hypernym or "~" for hyponym).
+func GetRelated(pos int, synsetOffset int, relationCode string) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	related, err := getRelated(wordnet, pos, synsetOffset, relationCode)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(related)
+}
+
+func getRelated(wn *gown.WN, pos int, offset int, relationCodes ...string) ([]*relatedSynset, error) {
+	if wn == nil {
+		return nil, ErrWordnetNotLoaded
+	}
+
+	ss := wn.GetSynset(pos, offset)
+	if ss == nil {
+		return nil, fmt.Errorf("no synset found for pos %d offset %d", pos, offset)
+	}
+
+	codes := map[string]bool{}
+	for _, code := range relationCodes {
+		codes[code] = true
+	}
+
+	var related []*relatedSynset
+	for _, ptr := range ss.Pointers {
+		if !codes[ptr.Symbol] {
+			continue
+		}
+		target := wn.GetSynset(ptr.Pos, ptr.Offset)
+		if target == nil {
+			continue
+		}
+		related = append(related, &relatedSynset{
+			Offset: target.Offset,
+			Pos:    target.Pos,
+			Words:  target.Words,
+		})
+	}
+	return related, nil
+}
+
+// Hypernyms returns a JSON-formatted string of the list of direct hypernym
+// synsets (IS-A parents) of the given synset.
+func Hypernyms(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, PtrHypernym, PtrInstanceHyper)
+}
+
+// Hyponyms returns a JSON-formatted string of the list of direct hyponym
+// synsets (IS-A children) of the given synset.
+func Hyponyms(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, PtrHyponym, PtrInstanceHypo)
+}
+
+// Meronyms returns a JSON-formatted string of the list of part/member/stuff
+// meronym synsets (HAS-A parts) of the given synset.
+func Meronyms(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, meronymCodes...)
+}
+
+// Holonyms returns a JSON-formatted string of the list of part/member/stuff
+// holonym synsets (HAS-A wholes) of the given synset.
+func Holonyms(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, holonymCodes...)
+}
+
+// Antonyms returns a JSON-formatted string of the list of antonym synsets
+// of the given synset.
+func Antonyms(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, PtrAntonym)
+}
+
+// DerivationallyRelated returns a JSON-formatted string of the list of
+// synsets derivationally related to the given synset.
+func DerivationallyRelated(pos int, synsetOffset int) string {
+	return getRelatedResponse(pos, synsetOffset, PtrDerivation)
+}
+
+func getRelatedResponse(pos int, synsetOffset int, relationCodes ...string) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	related, err := getRelated(wordnet, pos, synsetOffset, relationCodes...)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(related)
+}
+
+// TransitiveClosure walks the pointer relation graph rooted at the given
+// synset up to maxDepth hops, following edges whose pointer symbol matches
+// relationCode, and returns the resulting parent-to-children tree as a
+// JSON-formatted string. Cycles are broken by never revisiting a synset
+// already on the current path.
+func TransitiveClosure(pos int, synsetOffset int, relationCode string, maxDepth int) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	tree, err := transitiveClosure(wordnet, pos, synsetOffset, relationCode, maxDepth)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(tree)
+}
+
+func transitiveClosure(wn *gown.WN, pos int, offset int, relationCode string, maxDepth int) (*closureNode, error) {
+	if wn == nil {
+		return nil, ErrWordnetNotLoaded
+	}
+
+	root := wn.GetSynset(pos, offset)
+	if root == nil {
+		return nil, fmt.Errorf("no synset found for pos %d offset %d", pos, offset)
+	}
+
+	return buildClosureNode(wn.GetSynset, root, relationCode, maxDepth, map[string]bool{}), nil
+}
+
+// buildClosureNode walks relationCode edges via lookup (ordinarily
+// wn.GetSynset, swapped out in tests so cycles can be exercised without a
+// loaded dictionary). visited tracks the synsets on the current path, so a
+// back-edge into an ancestor stops recursion there instead of looping
+// forever.
+func buildClosureNode(lookup func(pos int, offset int) *gown.Synset, ss *gown.Synset, relationCode string, depthLeft int, visited map[string]bool) *closureNode {
+	node := &closureNode{
+		Offset: ss.Offset,
+		Pos:    ss.Pos,
+		Words:  ss.Words,
+	}
+
+	key := synsetKey(ss.Pos, ss.Offset)
+	if visited[key] || depthLeft <= 0 {
+		return node
+	}
+	visited[key] = true
+	defer delete(visited, key)
+
+	for _, ptr := range ss.Pointers {
+		if ptr.Symbol != relationCode {
+			continue
+		}
+		child := lookup(ptr.Pos, ptr.Offset)
+		if child == nil {
+			continue
+		}
+		node.Children = append(node.Children, buildClosureNode(lookup, child, relationCode, depthLeft-1, visited))
+	}
+	return node
+}
+
+func synsetKey(pos int, offset int) string {
+	return fmt.Sprintf("%d:%d", pos, offset)
+}
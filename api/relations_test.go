@@ -0,0 +1,97 @@
+package wordnet
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/wolfgangshilei/gown"
+)
+
+func TestGetRelated(t *testing.T) {
+	wn, err := load(dictDirName)
+	ensureWordnetLoaded(t, err)
+
+	testCases := []struct {
+		description  string
+		wn           *gown.WN
+		pos          int
+		offset       int
+		relationCode string
+		err          error
+		relatedNum   int
+	}{
+		{
+			description: "Test wordnet is not loaded.",
+			wn:          nil,
+			pos:         gown.POS_NOUN,
+			offset:      1,
+			err:         ErrWordnetNotLoaded,
+		},
+		{
+			description:  "Test hypernyms of an unknown synset.",
+			wn:           wn,
+			pos:          gown.POS_NOUN,
+			offset:       0,
+			relationCode: PtrHypernym,
+			err:          fmt.Errorf("no synset found for pos %d offset %d", gown.POS_NOUN, 0),
+		},
+	}
+
+	for _, tc := range testCases {
+		related, err := getRelated(tc.wn, tc.pos, tc.offset, tc.relationCode)
+		if !sameError(err, tc.err) {
+			t.Fatal("\nGot different error.\n", "Actual: ", err, "\nExpected: ", tc.err, "\n\n")
+		}
+
+		Convey(tc.description, t, func() {
+			So(len(related), ShouldEqual, tc.relatedNum)
+		})
+	}
+}
+
+func TestTransitiveClosureUnknownRoot(t *testing.T) {
+	wn, err := load(dictDirName)
+	ensureWordnetLoaded(t, err)
+
+	_, err = transitiveClosure(wn, gown.POS_NOUN, 0, PtrHypernym, 5)
+	if err == nil {
+		t.Fatal("expected an error for an unknown root synset")
+	}
+}
+
+func TestTransitiveClosureStopsOnCycle(t *testing.T) {
+	a := &gown.Synset{
+		Pos: gown.POS_NOUN, Offset: 1, Words: []string{"a"},
+		Pointers: []gown.Pointer{{Symbol: PtrHypernym, Pos: gown.POS_NOUN, Offset: 2}},
+	}
+	b := &gown.Synset{
+		Pos: gown.POS_NOUN, Offset: 2, Words: []string{"b"},
+		Pointers: []gown.Pointer{{Symbol: PtrHypernym, Pos: gown.POS_NOUN, Offset: 1}},
+	}
+
+	lookup := func(pos int, offset int) *gown.Synset {
+		switch offset {
+		case 1:
+			return a
+		case 2:
+			return b
+		}
+		return nil
+	}
+
+	tree := buildClosureNode(lookup, a, PtrHypernym, 10, map[string]bool{})
+
+	Convey("Test a hypernym cycle terminates instead of recursing forever.", t, func() {
+		So(tree.Offset, ShouldEqual, 1)
+		So(len(tree.Children), ShouldEqual, 1)
+
+		child := tree.Children[0]
+		So(child.Offset, ShouldEqual, 2)
+		So(len(child.Children), ShouldEqual, 1)
+
+		grandchild := child.Children[0]
+		So(grandchild.Offset, ShouldEqual, 1)
+		So(grandchild.Children, ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,283 @@
+package wordnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wolfgangshilei/gown"
+)
+
+const (
+	ssTypeNoun               = 1
+	ssTypeVerb               = 2
+	ssTypeAdjective          = 3
+	ssTypeAdverb             = 4
+	ssTypeAdjectiveSatellite = 5
+)
+
+// PtrSimilarTo is the pointer symbol linking a satellite adjective synset
+// to the head synset of its adjective cluster.
+const PtrSimilarTo = "&"
+
+type senseKeyParts struct {
+	Lemma      string
+	SSType     int
+	LexFilenum int
+	LexID      int
+	HeadWord   string
+	HeadID     int
+}
+
+// String renders the canonical sense key form
+// lemma%ss_type:lex_filenum:lex_id:head_word:head_id. Per the WordNet
+// senseidx spec, head_word/head_id are only meaningful for
+// adjective-satellite senses; a plain sense's key terminates with an
+// empty head field instead of a fabricated "00".
+func (k *senseKeyParts) String() string {
+	if k.HeadWord == "" {
+		return fmt.Sprintf("%s%%%d:%02d:%02d::", k.Lemma, k.SSType, k.LexFilenum, k.LexID)
+	}
+	return fmt.Sprintf("%s%%%d:%02d:%02d:%s:%02d", k.Lemma, k.SSType, k.LexFilenum, k.LexID, k.HeadWord, k.HeadID)
+}
+
+func ssTypeForPos(pos int) (int, error) {
+	switch pos {
+	case gown.POS_NOUN:
+		return ssTypeNoun, nil
+	case gown.POS_VERB:
+		return ssTypeVerb, nil
+	case gown.POS_ADJECTIVE:
+		return ssTypeAdjective, nil
+	case gown.POS_ADVERB:
+		return ssTypeAdverb, nil
+	case gown.POS_ADJECTIVE_SATELLITE:
+		return ssTypeAdjectiveSatellite, nil
+	}
+	return 0, fmt.Errorf("unknown part of speech %d", pos)
+}
+
+func posForSSType(ssType int) (int, error) {
+	switch ssType {
+	case ssTypeNoun:
+		return gown.POS_NOUN, nil
+	case ssTypeVerb:
+		return gown.POS_VERB, nil
+	case ssTypeAdjective:
+		return gown.POS_ADJECTIVE, nil
+	case ssTypeAdverb:
+		return gown.POS_ADVERB, nil
+	case ssTypeAdjectiveSatellite:
+		return gown.POS_ADJECTIVE_SATELLITE, nil
+	}
+	return 0, fmt.Errorf("unknown ss_type %d", ssType)
+}
+
+func parseSenseKey(key string) (*senseKeyParts, error) {
+	sepIdx := strings.Index(key, "%")
+	if sepIdx < 0 {
+		return nil, fmt.Errorf("malformed sense key %q: missing '%%'", key)
+	}
+
+	lemma := key[:sepIdx]
+	fields := strings.Split(key[sepIdx+1:], ":")
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("malformed sense key %q: expected 5 colon-separated fields", key)
+	}
+
+	ssType, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed sense key %q: %w", key, err)
+	}
+	lexFilenum, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed sense key %q: %w", key, err)
+	}
+	lexID, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed sense key %q: %w", key, err)
+	}
+
+	headID := 0
+	if fields[4] != "" {
+		if headID, err = strconv.Atoi(fields[4]); err != nil {
+			return nil, fmt.Errorf("malformed sense key %q: %w", key, err)
+		}
+	}
+
+	return &senseKeyParts{
+		Lemma:      lemma,
+		SSType:     ssType,
+		LexFilenum: lexFilenum,
+		LexID:      lexID,
+		HeadWord:   fields[3],
+		HeadID:     headID,
+	}, nil
+}
+
+// SenseKey returns a JSON-formatted string of the canonical WordNet sense
+// key (e.g. "test%1:04:00::") identifying the given lemma/pos/senseID
+// tuple.
+func SenseKey(lemma string, pos int, senseID int) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	key, err := buildSenseKey(wordnet, lemma, pos, senseID)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(key)
+}
+
+func buildSenseKey(wn *gown.WN, lemma string, pos int, senseID int) (string, error) {
+	ssType, err := ssTypeForPos(pos)
+	if err != nil {
+		return "", err
+	}
+
+	lemma = strings.ToLower(lemma)
+	sense := wn.LookupWithPartOfSpeechAndSense(lemma, pos, senseID)
+	if sense == nil {
+		return "", fmt.Errorf("no sense %d found for %q", senseID, lemma)
+	}
+	ss := sense.GetSynsetPtr()
+	if ss == nil {
+		return "", fmt.Errorf("no synset found for sense %d of %q", senseID, lemma)
+	}
+
+	headWord := ""
+	headID := 0
+	if pos == gown.POS_ADJECTIVE_SATELLITE {
+		if head := satelliteHead(wn, ss); head != nil && len(head.Words) > 0 {
+			headWord = strings.ToLower(head.Words[0])
+			headID = headWordLexID(wn, headWord, head)
+		}
+	}
+
+	parts := &senseKeyParts{
+		Lemma:      lemma,
+		SSType:     ssType,
+		LexFilenum: ss.Filenum,
+		LexID:      sense.LexID,
+		HeadWord:   headWord,
+		HeadID:     headID,
+	}
+	return parts.String(), nil
+}
+
+func satelliteHead(wn *gown.WN, ss *gown.Synset) *gown.Synset {
+	for _, ptr := range ss.Pointers {
+		if ptr.Symbol != PtrSimilarTo {
+			continue
+		}
+		if head := wn.GetSynset(ptr.Pos, ptr.Offset); head != nil {
+			return head
+		}
+	}
+	return nil
+}
+
+// headWordLexID returns the lex_id of headWord's sense that names head, so
+// a satellite's sense key carries the head word's real lex_id rather than
+// assuming it's always 0.
+func headWordLexID(wn *gown.WN, headWord string, head *gown.Synset) int {
+	for _, sense := range wn.LookupSensesWithPartOfSpeech(headWord, gown.POS_ADJECTIVE) {
+		if hs := sense.GetSynsetPtr(); hs != nil && hs.Offset == head.Offset {
+			return sense.LexID
+		}
+	}
+	return 0
+}
+
+// LookupBySenseKey returns a JSON-formatted string of the synset identified
+// by the given canonical WordNet sense key (e.g. "test%1:04:00::").
+func LookupBySenseKey(key string) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	synset, err := lookupBySenseKey(wordnet, key)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(synset)
+}
+
+func lookupBySenseKey(wn *gown.WN, key string) (*gown.Synset, error) {
+	parts, err := parseSenseKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := posForSSType(parts.SSType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sense := range wn.LookupSensesWithPartOfSpeech(parts.Lemma, pos) {
+		ss := sense.GetSynsetPtr()
+		if ss == nil || ss.Filenum != parts.LexFilenum || sense.LexID != parts.LexID {
+			continue
+		}
+		if pos == gown.POS_ADJECTIVE_SATELLITE && parts.HeadWord != "" {
+			head := satelliteHead(wn, ss)
+			if head == nil || len(head.Words) == 0 || strings.ToLower(head.Words[0]) != parts.HeadWord {
+				continue
+			}
+		}
+		return ss, nil
+	}
+	return nil, fmt.Errorf("no synset found for sense key %q", key)
+}
+
+// GetGloss returns a JSON-formatted string of the definition text of the
+// given synset's gloss, with example sentences removed.
+func GetGloss(pos int, synsetOffset int) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	definition, _, err := splitGlossOf(wordnet, pos, synsetOffset)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(definition)
+}
+
+// GetExamples returns a JSON-formatted string of the list of example
+// sentences quoted in the given synset's gloss.
+func GetExamples(pos int, synsetOffset int) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	_, examples, err := splitGlossOf(wordnet, pos, synsetOffset)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(examples)
+}
+
+func splitGlossOf(wn *gown.WN, pos int, offset int) (definition string, examples []string, err error) {
+	ss := wn.GetSynset(pos, offset)
+	if ss == nil {
+		return "", nil, fmt.Errorf("no synset found for pos %d offset %d", pos, offset)
+	}
+	definition, examples = splitGloss(ss.Gloss)
+	return definition, examples, nil
+}
+
+// splitGloss separates a WordNet gloss into its definition text and its
+// quoted example sentences, which are ";"-separated in the source data.
+func splitGloss(gloss string) (definition string, examples []string) {
+	var defParts []string
+	for _, part := range strings.Split(gloss, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "\"") && strings.HasSuffix(part, "\"") && len(part) >= 2 {
+			examples = append(examples, strings.Trim(part, "\""))
+		} else {
+			defParts = append(defParts, part)
+		}
+	}
+	return strings.Join(defParts, "; "), examples
+}
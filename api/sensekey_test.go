@@ -0,0 +1,126 @@
+package wordnet
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/wolfgangshilei/gown"
+)
+
+func TestParseSenseKey(t *testing.T) {
+	Convey("Test parsing a well-formed sense key.", t, func() {
+		parts, err := parseSenseKey("test%1:04:00::")
+		So(err, ShouldBeNil)
+		So(parts.Lemma, ShouldEqual, "test")
+		So(parts.SSType, ShouldEqual, ssTypeNoun)
+		So(parts.LexFilenum, ShouldEqual, 4)
+		So(parts.LexID, ShouldEqual, 0)
+		So(parts.HeadWord, ShouldEqual, "")
+		So(parts.HeadID, ShouldEqual, 0)
+	})
+
+	Convey("Test parsing a malformed sense key.", t, func() {
+		_, err := parseSenseKey("test-without-a-separator")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Test parsing a sense key with the wrong number of fields.", t, func() {
+		_, err := parseSenseKey("test%1:04:00")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestSenseKeyPartsString(t *testing.T) {
+	Convey("Test a non-satellite sense key has no head fields.", t, func() {
+		parts := &senseKeyParts{Lemma: "test", SSType: ssTypeNoun, LexFilenum: 4, LexID: 0}
+		So(parts.String(), ShouldEqual, "test%1:04:00::")
+	})
+
+	Convey("Test a satellite sense key carries its head word and lex_id.", t, func() {
+		parts := &senseKeyParts{
+			Lemma: "cold", SSType: ssTypeAdjectiveSatellite, LexFilenum: 0, LexID: 1,
+			HeadWord: "coldness", HeadID: 2,
+		}
+		So(parts.String(), ShouldEqual, "cold%5:00:01:coldness:02")
+	})
+}
+
+func TestSSTypePosRoundTrip(t *testing.T) {
+	testCases := []int{
+		gown.POS_NOUN,
+		gown.POS_VERB,
+		gown.POS_ADJECTIVE,
+		gown.POS_ADVERB,
+		gown.POS_ADJECTIVE_SATELLITE,
+	}
+
+	for _, pos := range testCases {
+		ssType, err := ssTypeForPos(pos)
+		Convey("Test ss_type/pos round trip.", t, func() {
+			So(err, ShouldBeNil)
+			roundTripped, err := posForSSType(ssType)
+			So(err, ShouldBeNil)
+			So(roundTripped, ShouldEqual, pos)
+		})
+	}
+}
+
+func TestSenseKeyRoundTrip(t *testing.T) {
+	wn, err := load(dictDirName)
+	ensureWordnetLoaded(t, err)
+
+	senses := wn.LookupSensesWithPartOfSpeech("test", gown.POS_NOUN)
+	if len(senses) == 0 {
+		t.Fatal("expected at least one noun sense for \"test\" in the test dictionary")
+	}
+
+	for senseID := range senses {
+		key, err := buildSenseKey(wn, "test", gown.POS_NOUN, senseID+1)
+		Convey("Test building a sense key from the real dictionary.", t, func() {
+			So(err, ShouldBeNil)
+		})
+
+		ss, err := lookupBySenseKey(wn, key)
+		Convey("Test resolving that sense key back to its synset.", t, func() {
+			So(err, ShouldBeNil)
+			So(ss, ShouldNotBeNil)
+			So(ss.Offset, ShouldEqual, senses[senseID].GetSynsetPtr().Offset)
+			So(ss.Filenum, ShouldEqual, senses[senseID].GetSynsetPtr().Filenum)
+		})
+	}
+}
+
+func TestSplitGloss(t *testing.T) {
+	testCases := []struct {
+		description        string
+		gloss              string
+		expectedDefinition string
+		expectedExamples   []string
+	}{
+		{
+			description:        "Test a gloss with no examples.",
+			gloss:              "a general conclusion",
+			expectedDefinition: "a general conclusion",
+		},
+		{
+			description:        "Test a gloss with one example.",
+			gloss:              `the act of testing something; "in the experimental trials the amount of carbon was measured separately"`,
+			expectedDefinition: "the act of testing something",
+			expectedExamples:   []string{"in the experimental trials the amount of carbon was measured separately"},
+		},
+		{
+			description:        "Test a gloss with multiple examples.",
+			gloss:              `a hypothetical description; "his narrow concept of the universe"; "a modified conception of the role of women"`,
+			expectedDefinition: "a hypothetical description",
+			expectedExamples:   []string{"his narrow concept of the universe", "a modified conception of the role of women"},
+		},
+	}
+
+	for _, tc := range testCases {
+		definition, examples := splitGloss(tc.gloss)
+		Convey(tc.description, t, func() {
+			So(definition, ShouldEqual, tc.expectedDefinition)
+			So(examples, ShouldResemble, tc.expectedExamples)
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package wordnet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wolfgangshilei/gown"
+)
+
+const minInformationContentProbability = 1e-9
+
+var errNoCommonAncestor = errors.New("no common ancestor")
+
+type similarityResult struct {
+	Score     float64 `json:"score"`
+	LCSOffset int     `json:"lcs_offset"`
+}
+
+var (
+	depthMu       sync.Mutex
+	maxDepthByPos = map[int]int{}
+)
+
+var (
+	icMu         sync.Mutex
+	icFreq       = map[string]float64{}
+	icTotalByPos = map[int]float64{}
+)
+
+// Similarity computes a WordNet semantic similarity score between the
+// best-scoring pair of synsets of lemma1/pos1 and lemma2/pos2, using the
+// given metric ("path", "wup" for Wu-Palmer, "lch" for Leacock-Chodorow or
+// "res" for Resnik), and returns a JSON-formatted string of the form
+// {"score": float, "lcs_offset": int}. If the two lemmas share no common
+// ancestor, a JSON error response is returned instead.
+func Similarity(lemma1 string, pos1 int, lemma2 string, pos2 int, metric string) string {
+	if wordnet == nil {
+		return makeJSONError(ErrWordnetNotLoaded)
+	}
+	result, err := similarity(wordnet, lemma1, pos1, lemma2, pos2, metric)
+	if err != nil {
+		return makeJSONError(err)
+	}
+	return makeJSONResponse(result)
+}
+
+func similarity(wn *gown.WN, lemma1 string, pos1 int, lemma2 string, pos2 int, metric string) (*similarityResult, error) {
+	synsets1, err := getSynsetsWithLemmaAndPos(wn, lemma1, pos1)
+	if err != nil {
+		return nil, err
+	}
+	synsets2, err := getSynsetsWithLemmaAndPos(wn, lemma2, pos2)
+	if err != nil {
+		return nil, err
+	}
+	if len(synsets1) == 0 {
+		return nil, fmt.Errorf("no synsets found for %q", lemma1)
+	}
+	if len(synsets2) == 0 {
+		return nil, fmt.Errorf("no synsets found for %q", lemma2)
+	}
+
+	var best *similarityResult
+	var pairErr error
+	for _, s1 := range synsets1 {
+		for _, s2 := range synsets2 {
+			res, err := similarityForPair(wn, s1, s2, metric)
+			if err != nil {
+				if !errors.Is(err, errNoCommonAncestor) {
+					pairErr = err
+				}
+				continue
+			}
+			if best == nil || res.Score > best.Score {
+				best = res
+			}
+		}
+	}
+	if best == nil {
+		if pairErr != nil {
+			return nil, pairErr
+		}
+		return nil, fmt.Errorf("%q and %q share no common ancestor", lemma1, lemma2)
+	}
+	return best, nil
+}
+
+func similarityForPair(wn *gown.WN, s1 *gown.Synset, s2 *gown.Synset, metric string) (*similarityResult, error) {
+	distances1 := hypernymDistances(wn, s1)
+	distances2 := hypernymDistances(wn, s2)
+
+	lcsKey := ""
+	pathLen := -1
+	for key, d1 := range distances1 {
+		d2, ok := distances2[key]
+		if !ok {
+			continue
+		}
+		if total := d1 + d2; pathLen == -1 || total < pathLen {
+			pathLen = total
+			lcsKey = key
+		}
+	}
+	if lcsKey == "" {
+		return nil, errNoCommonAncestor
+	}
+
+	lcsPos, lcsOffset, err := parseSynsetKey(lcsKey)
+	if err != nil {
+		return nil, err
+	}
+	lcs := wn.GetSynset(lcsPos, lcsOffset)
+	if lcs == nil {
+		return nil, fmt.Errorf("lowest common subsumer synset is missing")
+	}
+
+	switch metric {
+	case "path":
+		return &similarityResult{Score: 1.0 / float64(pathLen+1), LCSOffset: lcsOffset}, nil
+
+	case "wup":
+		depthLCS := float64(depthToRoot(wn.GetSynset, lcs))
+		depthS1 := float64(depthToRoot(wn.GetSynset, s1))
+		depthS2 := float64(depthToRoot(wn.GetSynset, s2))
+		if depthS1+depthS2 == 0 {
+			return nil, fmt.Errorf("cannot compute Wu-Palmer similarity for two root synsets")
+		}
+		return &similarityResult{Score: 2 * depthLCS / (depthS1 + depthS2), LCSOffset: lcsOffset}, nil
+
+	case "lch":
+		maxDepth := float64(maxTaxonomyDepth(s1.Pos))
+		if maxDepth <= 0 {
+			maxDepth = 1
+		}
+		return &similarityResult{
+			Score:     -math.Log(float64(pathLen+1) / (2 * maxDepth)),
+			LCSOffset: lcsOffset,
+		}, nil
+
+	case "res":
+		if !hasInformationContent(lcsPos) {
+			return nil, fmt.Errorf("no information content table loaded for pos %d; call LoadInformationContent first", lcsPos)
+		}
+		p := informationContentProbability(lcsPos, lcsOffset)
+		return &similarityResult{Score: -math.Log(p), LCSOffset: lcsOffset}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown similarity metric %q", metric)
+	}
+}
+
+// hypernymDistances returns, for every ancestor reachable from ss by
+// following hypernym pointers, the number of hops from ss to that
+// ancestor (0 for ss itself).
+func hypernymDistances(wn *gown.WN, ss *gown.Synset) map[string]int {
+	type item struct {
+		ss    *gown.Synset
+		depth int
+	}
+
+	distances := map[string]int{}
+	queue := []item{{ss, 0}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		key := synsetKey(cur.ss.Pos, cur.ss.Offset)
+		if d, seen := distances[key]; seen && d <= cur.depth {
+			continue
+		}
+		distances[key] = cur.depth
+
+		for _, ptr := range cur.ss.Pointers {
+			if ptr.Symbol != PtrHypernym && ptr.Symbol != PtrInstanceHyper {
+				continue
+			}
+			parent := wn.GetSynset(ptr.Pos, ptr.Offset)
+			if parent == nil {
+				continue
+			}
+			queue = append(queue, item{parent, cur.depth + 1})
+		}
+	}
+	return distances
+}
+
+// depthToRoot returns the shortest number of hypernym hops from ss up to a
+// synset with no further hypernym (i.e. a root of its taxonomy), resolving
+// parents via lookup (ordinarily wn.GetSynset, swapped out in tests). It is
+// a BFS over the hypernym pointers, so a synset reachable via multiple
+// parent chains gets its minimum depth rather than whichever chain is
+// followed first; a visited set guards against cycles.
+func depthToRoot(lookup func(pos int, offset int) *gown.Synset, ss *gown.Synset) int {
+	type item struct {
+		ss    *gown.Synset
+		depth int
+	}
+
+	visited := map[string]bool{}
+	queue := []item{{ss, 0}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		key := synsetKey(cur.ss.Pos, cur.ss.Offset)
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		hasParent := false
+		for _, ptr := range cur.ss.Pointers {
+			if ptr.Symbol != PtrHypernym && ptr.Symbol != PtrInstanceHyper {
+				continue
+			}
+			if parent := lookup(ptr.Pos, ptr.Offset); parent != nil {
+				hasParent = true
+				queue = append(queue, item{parent, cur.depth + 1})
+			}
+		}
+		if !hasParent {
+			return cur.depth
+		}
+	}
+	return 0
+}
+
+func setMaxTaxonomyDepth(pos int, depth int) {
+	depthMu.Lock()
+	defer depthMu.Unlock()
+	maxDepthByPos[pos] = depth
+}
+
+func maxTaxonomyDepth(pos int) int {
+	depthMu.Lock()
+	defer depthMu.Unlock()
+	return maxDepthByPos[pos]
+}
+
+// precomputeMaxDepths walks every synset of every part of speech in wn and
+// caches the deepest depthToRoot seen per POS, so Leacock-Chodorow scores
+// are computed against the real taxonomy depth instead of a guessed
+// constant.
+func precomputeMaxDepths(wn *gown.WN) {
+	for _, pos := range allPos() {
+		max := 0
+		for _, offset := range wn.AllSynsetOffsets(pos) {
+			ss := wn.GetSynset(pos, offset)
+			if ss == nil {
+				continue
+			}
+			if d := depthToRoot(wn.GetSynset, ss); d > max {
+				max = d
+			}
+		}
+		setMaxTaxonomyDepth(pos, max)
+	}
+}
+
+func parseSynsetKey(key string) (pos int, offset int, err error) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("malformed synset key %q", key)
+	}
+	if pos, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, err
+	}
+	if offset, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, err
+	}
+	return pos, offset, nil
+}
+
+// LoadInformationContent loads a Brown/SemCor-format information content
+// file (lines of "<offset><pos-letter> <frequency> [ROOT]") for use by the
+// Resnik similarity metric, and returns a JSON-formatted error response.
+func LoadInformationContent(path string) string {
+	return makeJSONError(loadInformationContent(path))
+}
+
+func loadInformationContent(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	freq := map[string]float64{}
+	totals := map[int]float64{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		pos, offset, err := parseOffsetPosLetter(fields[0])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+
+		freq[synsetKey(pos, offset)] += count
+
+		// Frequencies are cumulative: a synset's count already includes
+		// every hyponym's count, so summing every line would inflate the
+		// denominator roughly N-fold. The ROOT-flagged lines partition the
+		// taxonomy, so their counts alone sum to the POS's true corpus
+		// total.
+		if len(fields) >= 3 && fields[2] == "ROOT" {
+			totals[pos] += count
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	icMu.Lock()
+	icFreq = freq
+	icTotalByPos = totals
+	icMu.Unlock()
+	return nil
+}
+
+func parseOffsetPosLetter(s string) (pos int, offset int, err error) {
+	if len(s) < 2 {
+		return 0, 0, fmt.Errorf("malformed information content key %q", s)
+	}
+
+	letter := s[len(s)-1]
+	offset, err = strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch letter {
+	case 'n':
+		pos = gown.POS_NOUN
+	case 'v':
+		pos = gown.POS_VERB
+	case 'a':
+		pos = gown.POS_ADJECTIVE
+	case 'r':
+		pos = gown.POS_ADVERB
+	case 's':
+		pos = gown.POS_ADJECTIVE_SATELLITE
+	default:
+		return 0, 0, fmt.Errorf("unknown part-of-speech letter %q", string(letter))
+	}
+	return pos, offset, nil
+}
+
+func hasInformationContent(pos int) bool {
+	icMu.Lock()
+	defer icMu.Unlock()
+	return icTotalByPos[pos] > 0
+}
+
+func informationContentProbability(pos int, offset int) float64 {
+	icMu.Lock()
+	defer icMu.Unlock()
+
+	total := icTotalByPos[pos]
+	if total == 0 {
+		return minInformationContentProbability
+	}
+
+	freq := icFreq[synsetKey(pos, offset)]
+	if freq == 0 {
+		freq = 1
+	}
+
+	p := freq / total
+	if p < minInformationContentProbability {
+		return minInformationContentProbability
+	}
+	return p
+}
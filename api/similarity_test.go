@@ -0,0 +1,104 @@
+package wordnet
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/wolfgangshilei/gown"
+)
+
+func TestParseSynsetKey(t *testing.T) {
+	Convey("Test parsing a well-formed synset key.", t, func() {
+		pos, offset, err := parseSynsetKey(synsetKey(gown.POS_NOUN, 12345))
+		So(err, ShouldBeNil)
+		So(pos, ShouldEqual, gown.POS_NOUN)
+		So(offset, ShouldEqual, 12345)
+	})
+
+	Convey("Test parsing a malformed synset key.", t, func() {
+		_, _, err := parseSynsetKey("not-a-key")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestParseOffsetPosLetter(t *testing.T) {
+	testCases := []struct {
+		description string
+		key         string
+		pos         int
+		offset      int
+		wantErr     bool
+	}{
+		{"noun", "00001740n", gown.POS_NOUN, 1740, false},
+		{"verb", "00001740v", gown.POS_VERB, 1740, false},
+		{"unknown letter", "00001740z", 0, 0, true},
+		{"too short", "n", 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		pos, offset, err := parseOffsetPosLetter(tc.key)
+		Convey(tc.description, t, func() {
+			if tc.wantErr {
+				So(err, ShouldNotBeNil)
+				return
+			}
+			So(err, ShouldBeNil)
+			So(pos, ShouldEqual, tc.pos)
+			So(offset, ShouldEqual, tc.offset)
+		})
+	}
+}
+
+func TestInformationContentProbabilityWithoutICTable(t *testing.T) {
+	Convey("Test probability falls back to the floor when no IC table is loaded.", t, func() {
+		So(informationContentProbability(gown.POS_NOUN, 999999), ShouldEqual, minInformationContentProbability)
+	})
+}
+
+func TestResnikRequiresInformationContentTable(t *testing.T) {
+	Convey("Test hasInformationContent, which gates the res metric, reports no table before one is loaded.", t, func() {
+		So(hasInformationContent(gown.POS_NOUN), ShouldBeFalse)
+	})
+}
+
+func TestLoadInformationContentTotalsOnlyRoots(t *testing.T) {
+	path := t.TempDir() + "/test.ic"
+	contents := "1740n 3.0 ROOT\n1741n 1.0\n1742n 1.0\n1v 5.0 ROOT\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := loadInformationContent(path)
+	Convey("Test the corpus total only sums ROOT-flagged lines, not every cumulative count.", t, func() {
+		So(err, ShouldBeNil)
+		So(icTotalByPos[gown.POS_NOUN], ShouldEqual, 3.0)
+		So(icTotalByPos[gown.POS_VERB], ShouldEqual, 5.0)
+	})
+}
+
+func TestDepthToRootStopsOnCycle(t *testing.T) {
+	a := &gown.Synset{
+		Pos: gown.POS_NOUN, Offset: 1, Words: []string{"a"},
+		Pointers: []gown.Pointer{{Symbol: PtrHypernym, Pos: gown.POS_NOUN, Offset: 2}},
+	}
+	b := &gown.Synset{
+		Pos: gown.POS_NOUN, Offset: 2, Words: []string{"b"},
+		Pointers: []gown.Pointer{{Symbol: PtrHypernym, Pos: gown.POS_NOUN, Offset: 1}},
+	}
+
+	lookup := func(pos int, offset int) *gown.Synset {
+		switch offset {
+		case 1:
+			return a
+		case 2:
+			return b
+		}
+		return nil
+	}
+
+	Convey("Test a hypernym cycle between two synsets terminates instead of looping forever.", t, func() {
+		So(func() { depthToRoot(lookup, a) }, ShouldNotPanic)
+		So(depthToRoot(lookup, a), ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,289 @@
+// Command gown-server exposes the gown JSON wrapper over HTTP.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	wordnet "github.com/wolfgangshilei/gown/api"
+)
+
+var (
+	mu     sync.RWMutex
+	loaded bool
+)
+
+type loadRequest struct {
+	Dir string `json:"dir"`
+}
+
+type apiEnvelope struct {
+	Error string      `json:"error"`
+	Data  interface{} `json:"data"`
+}
+
+func main() {
+	listenAddr := flag.String("listen", ":8080", "address to listen on")
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lookup/", handleLookup)
+	mux.HandleFunc("/synset/", handleSynset)
+	mux.HandleFunc("/morph/", handleMorph)
+	mux.HandleFunc("/related/", handleRelated)
+	mux.HandleFunc("/load", handleLoad)
+
+	srv := &http.Server{
+		Addr:    *listenAddr,
+		Handler: withAuth(mux),
+	}
+
+	go func() {
+		log.Printf("gown-server listening on %s", *listenAddr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("gown-server: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatalf("gown-server: graceful shutdown failed: %v", err)
+	}
+}
+
+// withAuth enforces bearer-token auth when GOWN_SERVER_TOKEN is set, so the
+// server can be deployed behind a reverse proxy without extra glue.
+func withAuth(next http.Handler) http.Handler {
+	token := os.Getenv("GOWN_SERVER_TOKEN")
+	if token == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func handleLoad(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req loadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Dir == "" {
+		http.Error(w, `"dir" is required`, http.StatusBadRequest)
+		return
+	}
+
+	res := wordnet.Load(req.Dir)
+
+	var parsed struct {
+		Handle string `json:"handle"`
+		Error  string `json:"error"`
+	}
+	json.Unmarshal([]byte(res), &parsed)
+	if parsed.Error != "" {
+		http.Error(w, parsed.Error, http.StatusInternalServerError)
+		return
+	}
+
+	mu.Lock()
+	loaded = true
+	mu.Unlock()
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func handleLookup(w http.ResponseWriter, r *http.Request) {
+	if !requireLoaded(w) {
+		return
+	}
+
+	segments := pathSegments(r.URL.Path, "/lookup/")
+	if len(segments) == 0 || segments[0] == "" {
+		http.Error(w, "lemma is required", http.StatusBadRequest)
+		return
+	}
+	lemma := segments[0]
+
+	if len(segments) >= 2 && segments[1] != "" {
+		pos, err := strconv.Atoi(segments[1])
+		if err != nil {
+			http.Error(w, "invalid part of speech", http.StatusBadRequest)
+			return
+		}
+		writeAPIResult(w, wordnet.LookupWithPartOfSpeech(lemma, pos))
+		return
+	}
+
+	writeAPIResult(w, wordnet.Lookup(lemma))
+}
+
+func handleSynset(w http.ResponseWriter, r *http.Request) {
+	if !requireLoaded(w) {
+		return
+	}
+
+	segments := pathSegments(r.URL.Path, "/synset/")
+	if len(segments) != 2 {
+		http.Error(w, "expected /synset/{pos}/{offset}", http.StatusBadRequest)
+		return
+	}
+
+	pos, err := strconv.Atoi(segments[0])
+	if err != nil {
+		http.Error(w, "invalid part of speech", http.StatusBadRequest)
+		return
+	}
+	offset, err := strconv.Atoi(segments[1])
+	if err != nil {
+		http.Error(w, "invalid synset offset", http.StatusBadRequest)
+		return
+	}
+
+	writeAPIResult(w, wordnet.GetSynset(pos, offset))
+}
+
+func handleMorph(w http.ResponseWriter, r *http.Request) {
+	if !requireLoaded(w) {
+		return
+	}
+
+	segments := pathSegments(r.URL.Path, "/morph/")
+	if len(segments) != 1 || segments[0] == "" {
+		http.Error(w, "word is required", http.StatusBadRequest)
+		return
+	}
+
+	writeAPIResult(w, wordnet.Morph(segments[0]))
+}
+
+func handleRelated(w http.ResponseWriter, r *http.Request) {
+	if !requireLoaded(w) {
+		return
+	}
+
+	segments := pathSegments(r.URL.Path, "/related/")
+	if len(segments) != 3 {
+		http.Error(w, "expected /related/{pos}/{offset}/{relation}", http.StatusBadRequest)
+		return
+	}
+
+	pos, err := strconv.Atoi(segments[0])
+	if err != nil {
+		http.Error(w, "invalid part of speech", http.StatusBadRequest)
+		return
+	}
+	offset, err := strconv.Atoi(segments[1])
+	if err != nil {
+		http.Error(w, "invalid synset offset", http.StatusBadRequest)
+		return
+	}
+
+	writeAPIResult(w, wordnet.GetRelated(pos, offset, segments[2]))
+}
+
+func requireLoaded(w http.ResponseWriter) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if !loaded {
+		http.Error(w, "wordnet is not loaded", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// writeAPIResult translates one of the package's JSON envelope strings
+// ({"error":...} or {"data":...}) into the proper HTTP status code, rather
+// than always answering 200 with the error wrapped inside.
+func writeAPIResult(w http.ResponseWriter, res string) {
+	var envelope apiEnvelope
+	json.Unmarshal([]byte(res), &envelope)
+
+	if envelope.Error != "" {
+		http.Error(w, envelope.Error, statusForError(envelope.Error))
+		return
+	}
+	if isEmptyData(envelope.Data) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// isEmptyData reports whether data is JSON null, or an array/object that
+// decoded to zero elements — gown returns an empty (but non-nil) slice for
+// a lemma with no senses just as often as it returns null, and both mean
+// the lookup found nothing.
+func isEmptyData(data interface{}) bool {
+	if data == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+// statusForError maps a gown API error message to the HTTP status it
+// corresponds to, since the wordnet package communicates failure reasons
+// as plain error strings rather than typed errors.
+func statusForError(msg string) int {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "not loaded"):
+		return http.StatusServiceUnavailable
+	case strings.Contains(lower, "no synset"), strings.Contains(lower, "no sense"),
+		strings.Contains(lower, "not found"), strings.Contains(lower, "no common ancestor"),
+		strings.Contains(lower, "share no common ancestor"):
+		return http.StatusNotFound
+	case strings.Contains(lower, "unknown"), strings.Contains(lower, "malformed"),
+		strings.Contains(lower, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+func pathSegments(path string, prefix string) []string {
+	trimmed := strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}